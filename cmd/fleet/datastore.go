@@ -0,0 +1,16 @@
+package main
+
+import (
+	"github.com/fleetdm/fleet/v4/server/datastore/inmem"
+	"github.com/fleetdm/fleet/v4/server/fleet"
+	"github.com/urfave/cli/v2"
+)
+
+// newDatastore constructs the server's datastore. --datastore-file
+// optionally seeds it with real users/policies; omitting the flag still
+// starts the server, it just means --client-ca has no certificate-to-user
+// mappings and there are no policies to evaluate for the failing-policies
+// webhook until the file is configured.
+func newDatastore(c *cli.Context) (fleet.Datastore, error) {
+	return inmem.New(c.String("datastore-file"))
+}
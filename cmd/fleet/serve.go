@@ -0,0 +1,94 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+
+	"github.com/fleetdm/fleet/v4/server/fleet"
+	"github.com/fleetdm/fleet/v4/server/service"
+	"github.com/fleetdm/fleet/v4/server/webhooks"
+	"github.com/urfave/cli/v2"
+)
+
+func serveCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "Launch the Fleet server",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "listen-socket",
+				Usage: "Path to a UNIX domain socket to additionally serve the API on, for admin-only access from co-located services",
+			},
+			&cli.StringFlag{
+				Name:  "client-ca",
+				Usage: "PEM file of the CA to verify client certificates against, enabling mutual TLS as an alternative to a fleetctl login token",
+			},
+			&cli.StringFlag{
+				Name:  "server-cert",
+				Usage: "PEM file of the server's TLS certificate (required when --client-ca is set)",
+			},
+			&cli.StringFlag{
+				Name:  "server-key",
+				Usage: "PEM file of the server's TLS private key (required when --client-ca is set)",
+			},
+			&cli.StringFlag{
+				Name:  "server-address",
+				Usage: "Address to serve the API on",
+				Value: ":8080",
+			},
+			&cli.StringFlag{
+				Name:  "datastore-file",
+				Usage: "Path to a JSON file seeding the server's datastore (users, policies), for deployments not backed by the full MySQL datastore",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			ds, err := newDatastore(c)
+			if err != nil {
+				return fmt.Errorf("configure datastore: %w", err)
+			}
+
+			mux := newMux(ds)
+
+			if socketPath := c.String("listen-socket"); socketPath != "" {
+				listener, err := listenSocket(socketPath, mux)
+				if err != nil {
+					return fmt.Errorf("listen on socket %q: %w", socketPath, err)
+				}
+				defer listener.Close()
+			}
+
+			tlsConfig, err := clientCATLSConfig(c)
+			if err != nil {
+				return fmt.Errorf("configure client CA: %w", err)
+			}
+			if tlsConfig != nil {
+				mux = service.ClientCertificateMiddleware(ds)(mux)
+			}
+
+			return serveHTTP(c, mux, tlsConfig)
+		},
+	}
+}
+
+// newMux builds the HTTP mux the server handles API requests with. It is
+// shared between the network listener and --listen-socket so that both
+// expose the same API.
+func newMux(ds fleet.Datastore) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/latest/fleet/webhooks/dead_letter/replay", webhooks.ReplayDeadLetterHandler(ds))
+	mux.HandleFunc("/api/latest/fleet/login", service.LoginHandler(ds))
+	mux.HandleFunc("/api/latest/fleet/token/refresh", service.TokenRefreshHandler(ds))
+	return mux
+}
+
+// serveHTTP runs the server's normal network listener, applying tlsConfig
+// (e.g. the --client-ca mutual TLS settings) when set. It runs a plain
+// http.Server until that returns an error; there is no graceful shutdown.
+func serveHTTP(c *cli.Context, mux http.Handler, tlsConfig *tls.Config) error {
+	server := &http.Server{Addr: c.String("server-address"), Handler: mux, TLSConfig: tlsConfig}
+	if tlsConfig != nil {
+		return server.ListenAndServeTLS(c.String("server-cert"), c.String("server-key"))
+	}
+	return server.ListenAndServe()
+}
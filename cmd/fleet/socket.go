@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+)
+
+// listenSocket binds a UNIX domain socket at path and serves handler on it.
+// The socket file is removed and recreated on each call (so a stale socket
+// left behind by an unclean shutdown doesn't block startup), and its
+// filesystem permissions are locked down to owner-only so that only
+// co-located, same-user processes can reach the admin API without a bearer
+// token.
+func listenSocket(path string, handler http.Handler) (net.Listener, error) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("remove existing socket %q: %w", path, err)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listen on socket %q: %w", path, err)
+	}
+
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("chmod socket %q: %w", path, err)
+	}
+
+	go func() {
+		//nolint:errcheck
+		http.Serve(listener, handler)
+	}()
+
+	return listener, nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -8,10 +9,12 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"runtime"
+	"time"
 
 	"github.com/fleetdm/fleet/v4/pkg/fleethttp"
 	"github.com/fleetdm/fleet/v4/server/service"
@@ -59,6 +62,14 @@ func clientFromCLI(c *cli.Context) (*service.Client, error) {
 	}
 	fleet.SetToken(token)
 
+	if cc, err := clientConfigFromCLI(c); err == nil && cc.RefreshToken != "" {
+		tokens := tokenSet{AccessToken: token, RefreshToken: cc.RefreshToken}
+		if cc.TokenExpiry != 0 {
+			tokens.Expiry = time.Unix(cc.TokenExpiry, 0)
+		}
+		fleet.SetTransport(newRefreshingTransport(fleet.Transport(), cc.Address, cc.URLPrefix, configPath, context, tokens))
+	}
+
 	// Check if version matches fleet server. Also ensures that the token is valid.
 	clientInfo := version.Version()
 
@@ -90,6 +101,19 @@ func unauthenticatedClientFromConfig(cc Context, debug bool, w io.Writer) (*serv
 			os.Getenv("FLEET_SERVER_ADDRESS"), true, "", "", options...)
 	}
 
+	if cc.Socket != "" {
+		if debug {
+			options = append(options, service.EnableClientDebug())
+		}
+		options = append(options, service.WithCustomTransport(unixTransport(cc.Socket)))
+
+		fleet, err := service.NewClient("http://unix", true, "", cc.URLPrefix, options...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating Fleet API client handler: %w", err)
+		}
+		return fleet, nil
+	}
+
 	if cc.Address == "" {
 		return nil, errors.New("set the Fleet API address with: fleetctl config set --address https://localhost:8080")
 	}
@@ -102,6 +126,14 @@ func unauthenticatedClientFromConfig(cc Context, debug bool, w io.Writer) (*serv
 		options = append(options, service.EnableClientDebug())
 	}
 
+	cert, err := clientTLSCertificate(cc)
+	if err != nil {
+		return nil, err
+	}
+	if cert != nil {
+		options = append(options, service.WithClientCertificate(*cert))
+	}
+
 	fleet, err := service.NewClient(
 		cc.Address,
 		cc.TLSSkipVerify,
@@ -125,6 +157,16 @@ func rawHTTPClientFromConfig(cc Context) (*http.Client, *url.URL, error) {
 	if flag.Lookup("test.v") != nil {
 		cc.Address = os.Getenv("FLEET_SERVER_ADDRESS")
 	}
+
+	if cc.Socket != "" {
+		baseURL, err := url.Parse("http://unix")
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse socket address: %w", err)
+		}
+		cli := &http.Client{Transport: unixTransport(cc.Socket)}
+		return cli, baseURL, nil
+	}
+
 	baseURL, err := url.Parse(cc.Address)
 	if err != nil {
 		return nil, nil, fmt.Errorf("parse address: %w", err)
@@ -145,13 +187,57 @@ func rawHTTPClientFromConfig(cc Context) (*http.Client, *url.URL, error) {
 		}
 	}
 
-	cli := fleethttp.NewClient(fleethttp.WithTLSClientConfig(&tls.Config{
+	tlsConfig := &tls.Config{
 		InsecureSkipVerify: cc.TLSSkipVerify,
 		RootCAs:            rootCA,
-	}))
+	}
+	cert, err := clientTLSCertificate(cc)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*cert}
+	}
+
+	cli := fleethttp.NewClient(fleethttp.WithTLSClientConfig(tlsConfig))
 	return cli, baseURL, nil
 }
 
+// clientTLSCertificate loads the client certificate configured on a context
+// for mutual TLS, returning (nil, nil) when none is configured. This lets
+// deployments that disallow long-lived API tokens authenticate fleetctl
+// purely from a PKI-issued certificate instead.
+func clientTLSCertificate(cc Context) (*tls.Certificate, error) {
+	switch {
+	case cc.TLSCert == "" && cc.TLSKey == "":
+		return nil, nil
+	case cc.TLSCert == "" || cc.TLSKey == "":
+		return nil, errors.New("both tls_cert and tls_key must be set to use mutual TLS")
+	}
+
+	cert, err := tls.LoadX509KeyPair(cc.TLSCert, cc.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("load client certificate: %w", err)
+	}
+
+	return &cert, nil
+}
+
+// unixTransport returns an http.RoundTripper that dials the given UNIX
+// domain socket path instead of a network address. It is used when a
+// fleetctl context is configured with Context.Socket, which is typical for
+// admin-only tooling running co-located with the Fleet server that wants to
+// avoid issuing a bearer token or managing certificates for loopback
+// traffic.
+func unixTransport(path string) *http.Transport {
+	return &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		},
+	}
+}
+
 func clientConfigFromCLI(c *cli.Context) (Context, error) {
 	if flag.Lookup("test.v") != nil {
 		return Context{
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultContext = "default"
+
+// Context is the set of configuration values that are scoped to a single
+// Fleet server (address, credentials, TLS settings, etc). fleetctl supports
+// multiple contexts so that a single config file can be used against
+// several Fleet deployments.
+type Context struct {
+	Address       string `json:"address" yaml:"address"`
+	Email         string `json:"email" yaml:"email"`
+	Token         string `json:"token" yaml:"token"`
+	RootCA        string `json:"root_ca" yaml:"rootca"`
+	URLPrefix     string `json:"url_prefix" yaml:"url-prefix"`
+	TLSSkipVerify bool   `json:"tls_skip_verify" yaml:"tls-skip-verify"`
+	// Socket is the path to a UNIX domain socket the Fleet server is
+	// listening on. When set, Address/RootCA/TLSSkipVerify are ignored:
+	// traffic never leaves the host, so there is nothing to authenticate
+	// or encrypt.
+	Socket string `json:"socket" yaml:"socket"`
+	// TLSCert and TLSKey are PEM file paths for a client certificate used
+	// for mutual TLS. When both are set, the client presents this
+	// certificate to the server as an alternative to a bearer token
+	// obtained via `fleetctl login`.
+	TLSCert string `json:"tls_cert" yaml:"tls-cert"`
+	TLSKey  string `json:"tls_key" yaml:"tls-key"`
+	// RefreshToken and TokenExpiry (unix seconds) accompany Token (the
+	// access token) so the client can transparently refresh before Token
+	// expires instead of requiring another `fleetctl login`. TokenExpiry
+	// is 0 for contexts using a long-lived token with no refresh token.
+	RefreshToken string `json:"refresh_token" yaml:"refresh-token"`
+	TokenExpiry  int64  `json:"token_expiry" yaml:"token-expiry"`
+}
+
+// Config is the on-disk format of the fleetctl config file (~/.fleet/config
+// by default).
+type Config struct {
+	Contexts map[string]Context `json:"contexts" yaml:"contexts"`
+}
+
+func makeConfigIfNotExists(fp string) error {
+	_, err := os.Stat(fp)
+	if err == nil {
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("stat config path: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fp), os.FileMode(0700)); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+
+	emptyConfig := Config{Contexts: map[string]Context{defaultContext: {}}}
+	b, err := yaml.Marshal(emptyConfig)
+	if err != nil {
+		return fmt.Errorf("marshal new config: %w", err)
+	}
+
+	if err := ioutil.WriteFile(fp, b, os.FileMode(0600)); err != nil {
+		return fmt.Errorf("write new config: %w", err)
+	}
+
+	return nil
+}
+
+func readConfig(fp string) (Config, error) {
+	var c Config
+
+	b, err := ioutil.ReadFile(fp)
+	if err != nil {
+		return Config{}, fmt.Errorf("read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(b, &c); err != nil {
+		return Config{}, fmt.Errorf("unmarshal config: %w", err)
+	}
+
+	if c.Contexts == nil {
+		c.Contexts = map[string]Context{}
+	}
+
+	return c, nil
+}
+
+func writeConfig(fp string, c Config) error {
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+
+	if err := ioutil.WriteFile(fp, b, os.FileMode(0600)); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+
+	return nil
+}
+
+// getConfigValue reads a single named value out of the given context,
+// rather than the whole typed Context struct, so that callers can fetch
+// loosely-typed or sensitive fields (such as the auth token) without
+// requiring every caller to thread a full Context through.
+func getConfigValue(fp, context, key string) (interface{}, error) {
+	c, err := readConfig(fp)
+	if err != nil {
+		return nil, err
+	}
+
+	if context == "" {
+		context = defaultContext
+	}
+
+	cc, ok := c.Contexts[context]
+	if !ok {
+		return nil, fmt.Errorf("context %q is not found", context)
+	}
+
+	var m map[string]interface{}
+	b, err := yaml.Marshal(cc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal context: %w", err)
+	}
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("unmarshal context: %w", err)
+	}
+
+	v, ok := m[key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in context %q", key, context)
+	}
+
+	return v, nil
+}
+
+func setConfigValue(fp, context, key string, value interface{}) error {
+	c, err := readConfig(fp)
+	if err != nil {
+		return err
+	}
+
+	if context == "" {
+		context = defaultContext
+	}
+
+	cc := c.Contexts[context]
+
+	var m map[string]interface{}
+	b, err := yaml.Marshal(cc)
+	if err != nil {
+		return fmt.Errorf("marshal context: %w", err)
+	}
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return fmt.Errorf("unmarshal context: %w", err)
+	}
+	m[key] = value
+
+	b, err = yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshal updated context: %w", err)
+	}
+	var updated Context
+	if err := yaml.Unmarshal(b, &updated); err != nil {
+		return fmt.Errorf("unmarshal updated context: %w", err)
+	}
+
+	c.Contexts[context] = updated
+
+	return writeConfig(fp, c)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigRoundTripsRefreshTokenFields(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "config")
+
+	written := Config{
+		Contexts: map[string]Context{
+			defaultContext: {
+				Address:      "https://fleet.example.com",
+				Token:        "access-token",
+				RefreshToken: "refresh-token",
+				TokenExpiry:  1234567890,
+			},
+		},
+	}
+	require.NoError(t, writeConfig(fp, written))
+
+	read, err := readConfig(fp)
+	require.NoError(t, err)
+
+	got := read.Contexts[defaultContext]
+	assert.Equal(t, "access-token", got.Token)
+	assert.Equal(t, "refresh-token", got.RefreshToken)
+	assert.Equal(t, int64(1234567890), got.TokenExpiry)
+}
+
+func TestMakeConfigIfNotExistsIsIdempotent(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "config")
+
+	require.NoError(t, makeConfigIfNotExists(fp))
+	b1, err := ioutil.ReadFile(fp)
+	require.NoError(t, err)
+
+	require.NoError(t, makeConfigIfNotExists(fp))
+	b2, err := ioutil.ReadFile(fp)
+	require.NoError(t, err)
+
+	assert.Equal(t, b1, b2)
+}
+
+func TestPersistTokensUpdatesContext(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "config")
+	require.NoError(t, makeConfigIfNotExists(fp))
+
+	require.NoError(t, os.Chmod(fp, 0600))
+	require.NoError(t, persistTokens(fp, "", tokenSet{
+		AccessToken:  "new-access",
+		RefreshToken: "new-refresh",
+	}))
+
+	c, err := readConfig(fp)
+	require.NoError(t, err)
+	ctx := c.Contexts[defaultContext]
+	assert.Equal(t, "new-access", ctx.Token)
+	assert.Equal(t, "new-refresh", ctx.RefreshToken)
+}
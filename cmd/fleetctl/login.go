@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+func loginCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "login",
+		Usage:     "Login to Fleet",
+		UsageText: `fleetctl login --email <email> --password <password>`,
+		Flags: []cli.Flag{
+			configFlag(),
+			contextFlag(),
+			debugFlag(),
+			&cli.StringFlag{
+				Name:  "email",
+				Usage: "Email to authenticate with",
+			},
+			&cli.StringFlag{
+				Name:  "password",
+				Usage: "Password to authenticate with",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			email := c.String("email")
+			if email == "" {
+				return errors.New("--email is required")
+			}
+			password := c.String("password")
+			if password == "" {
+				return errors.New("--password is required")
+			}
+
+			fleet, err := unauthenticatedClientFromCLI(c)
+			if err != nil {
+				return err
+			}
+
+			result, err := fleet.Login(email, password)
+			if err != nil {
+				return fmt.Errorf("login: %w", err)
+			}
+
+			configPath, context := c.String("config"), c.String("context")
+			if err := persistTokens(configPath, context, tokenSet{
+				AccessToken:  result.AccessToken,
+				RefreshToken: result.RefreshToken,
+				Expiry:       time.Now().Add(time.Duration(result.ExpiresIn) * time.Second),
+			}); err != nil {
+				return fmt.Errorf("persist login tokens: %w", err)
+			}
+
+			fmt.Fprintln(c.App.Writer, "Login successful.")
+			return nil
+		},
+	}
+}
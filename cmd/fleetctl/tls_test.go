@@ -0,0 +1,19 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientTLSCertificateRequiresBoth(t *testing.T) {
+	cert, err := clientTLSCertificate(Context{})
+	assert.NoError(t, err)
+	assert.Nil(t, cert)
+
+	_, err = clientTLSCertificate(Context{TLSCert: "cert.pem"})
+	assert.Error(t, err)
+
+	_, err = clientTLSCertificate(Context{TLSKey: "key.pem"})
+	assert.Error(t, err)
+}
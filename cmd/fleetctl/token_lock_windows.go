@@ -0,0 +1,11 @@
+//go:build windows
+
+package main
+
+// lockConfigFile is a no-op on Windows: fleetctl doesn't currently expect
+// concurrent invocations sharing a config file on that platform, and
+// syscall.Flock isn't available there. The returned func is a no-op
+// release.
+func lockConfigFile(path string) (func(), error) {
+	return func() {}, nil
+}
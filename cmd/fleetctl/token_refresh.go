@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fleetdm/fleet/v4/server/service"
+)
+
+// refreshSkew is how far ahead of expiry a refresh is triggered, mirroring
+// the skew window used by oauth2.TokenSource implementations so that a
+// request doesn't race a token expiring mid-flight.
+const refreshSkew = 60 * time.Second
+
+// tokenSet is the access/refresh token triple fleetctl persists per
+// context.
+type tokenSet struct {
+	AccessToken  string
+	RefreshToken string
+	Expiry       time.Time
+}
+
+// refreshingTransport wraps an http.RoundTripper, attaching the current
+// access token to each outgoing request and refreshing it via the Fleet
+// token/refresh endpoint whenever it's within refreshSkew of expiring. This
+// follows the access-token-plus-refresh-token pattern adopted by other Go
+// admin CLIs: short-lived access tokens limit the blast radius of a leaked
+// config file, while the refresh token lets fleetctl stay logged in across
+// sessions without requiring `fleetctl login` again.
+type refreshingTransport struct {
+	base       http.RoundTripper
+	address    string
+	urlPrefix  string
+	configPath string
+	context    string
+
+	mu     sync.Mutex
+	tokens tokenSet
+}
+
+func newRefreshingTransport(base http.RoundTripper, address, urlPrefix, configPath, context string, tokens tokenSet) *refreshingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &refreshingTransport{base: base, address: address, urlPrefix: urlPrefix, configPath: configPath, context: context, tokens: tokens}
+}
+
+func (t *refreshingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	tokens := t.tokens
+	t.mu.Unlock()
+
+	if !tokens.Expiry.IsZero() && time.Now().Add(refreshSkew).After(tokens.Expiry) {
+		refreshed, err := t.refresh(req.Context(), tokens)
+		if err != nil {
+			return nil, fmt.Errorf("%w: refreshing access token: %s", service.ErrUnauthenticated, err)
+		}
+
+		t.mu.Lock()
+		t.tokens = refreshed
+		t.mu.Unlock()
+		tokens = refreshed
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+
+	return t.base.RoundTrip(req)
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type refreshResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+func (t *refreshingTransport) refresh(ctx context.Context, current tokenSet) (tokenSet, error) {
+	body, err := json.Marshal(refreshRequest{RefreshToken: current.RefreshToken})
+	if err != nil {
+		return tokenSet{}, fmt.Errorf("marshal refresh request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.address+strings.TrimRight(t.urlPrefix, "/")+"/api/latest/fleet/token/refresh", bytes.NewReader(body))
+	if err != nil {
+		return tokenSet{}, fmt.Errorf("create refresh request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := (&http.Client{Transport: t.base, Timeout: 10 * time.Second}).Do(req)
+	if err != nil {
+		return tokenSet{}, fmt.Errorf("do refresh request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return tokenSet{}, fmt.Errorf("unexpected status %d refreshing token", resp.StatusCode)
+	}
+
+	var rr refreshResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rr); err != nil {
+		return tokenSet{}, fmt.Errorf("decode refresh response: %w", err)
+	}
+
+	next := tokenSet{
+		AccessToken:  rr.AccessToken,
+		RefreshToken: rr.RefreshToken,
+		Expiry:       time.Now().Add(time.Duration(rr.ExpiresIn) * time.Second),
+	}
+
+	if err := persistTokens(t.configPath, t.context, next); err != nil {
+		return tokenSet{}, fmt.Errorf("persist refreshed tokens: %w", err)
+	}
+
+	return next, nil
+}
+
+// persistTokens writes the rotated token triple back to the fleetctl
+// config file, holding an exclusive file lock (see token_lock_*.go) for the
+// read-modify-write so that concurrent fleetctl invocations sharing a
+// config don't race and clobber each other's refreshed tokens.
+func persistTokens(configPath, context string, tokens tokenSet) error {
+	unlock, err := lockConfigFile(configPath)
+	if err != nil {
+		return fmt.Errorf("lock config: %w", err)
+	}
+	defer unlock()
+
+	c, err := readConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	if context == "" {
+		context = defaultContext
+	}
+
+	cc := c.Contexts[context]
+	cc.Token = tokens.AccessToken
+	cc.RefreshToken = tokens.RefreshToken
+	cc.TokenExpiry = tokens.Expiry.Unix()
+	c.Contexts[context] = cc
+
+	return writeConfig(configPath, c)
+}
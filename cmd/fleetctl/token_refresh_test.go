@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshingTransportRefreshesNearExpiry(t *testing.T) {
+	dir := t.TempDir()
+	fp := dir + "/config"
+	require.NoError(t, makeConfigIfNotExists(fp))
+
+	var refreshCalls int
+	var sawAuthHeader string
+
+	refreshServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/latest/fleet/token/refresh" {
+			refreshCalls++
+			_ = json.NewEncoder(w).Encode(refreshResponse{
+				AccessToken:  "refreshed-access",
+				RefreshToken: "refreshed-refresh",
+				ExpiresIn:    3600,
+			})
+			return
+		}
+		sawAuthHeader = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer refreshServer.Close()
+
+	transport := newRefreshingTransport(http.DefaultTransport, refreshServer.URL, "", fp, "", tokenSet{
+		AccessToken:  "stale-access",
+		RefreshToken: "stale-refresh",
+		Expiry:       time.Now().Add(1 * time.Second), // within refreshSkew
+	})
+
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get(refreshServer.URL + "/api/latest/fleet/version")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, 1, refreshCalls)
+	assert.Equal(t, "Bearer refreshed-access", sawAuthHeader)
+
+	c, err := readConfig(fp)
+	require.NoError(t, err)
+	assert.Equal(t, "refreshed-access", c.Contexts[defaultContext].Token)
+	assert.Equal(t, "refreshed-refresh", c.Contexts[defaultContext].RefreshToken)
+}
+
+func TestRefreshingTransportSkipsRefreshWhenTokenFresh(t *testing.T) {
+	var refreshCalls int
+	var sawAuthHeader string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/latest/fleet/token/refresh" {
+			refreshCalls++
+		}
+		sawAuthHeader = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := newRefreshingTransport(http.DefaultTransport, server.URL, "", "", "", tokenSet{
+		AccessToken: "still-good",
+		Expiry:      time.Now().Add(time.Hour),
+	})
+
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get(server.URL + "/api/latest/fleet/version")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, 0, refreshCalls)
+	assert.Equal(t, "Bearer still-good", sawAuthHeader)
+}
+
+func TestRefreshingTransportHonorsURLPrefix(t *testing.T) {
+	dir := t.TempDir()
+	fp := dir + "/config"
+	require.NoError(t, makeConfigIfNotExists(fp))
+
+	var refreshedPath string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			refreshedPath = r.URL.Path
+			_ = json.NewEncoder(w).Encode(refreshResponse{AccessToken: "refreshed-access", ExpiresIn: 3600})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := newRefreshingTransport(http.DefaultTransport, server.URL, "/prefix", fp, "", tokenSet{
+		AccessToken:  "stale-access",
+		RefreshToken: "stale-refresh",
+		Expiry:       time.Now().Add(1 * time.Second), // within refreshSkew
+	})
+
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get(server.URL + "/prefix/api/latest/fleet/version")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "/prefix/api/latest/fleet/token/refresh", refreshedPath)
+}
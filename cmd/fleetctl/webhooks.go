@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+func webhooksCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "webhooks",
+		Usage: "Manage webhook deliveries",
+		Subcommands: []*cli.Command{
+			webhooksReplayCommand(),
+		},
+	}
+}
+
+func webhooksReplayCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "replay",
+		Usage:     "Re-send dead-lettered webhook deliveries",
+		UsageText: `fleetctl webhooks replay [--id <id>]`,
+		Flags: []cli.Flag{
+			configFlag(),
+			contextFlag(),
+			debugFlag(),
+			&cli.UintFlag{
+				Name:  "id",
+				Usage: "Replay only the dead-letter entry with this ID (default: replay all)",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			client, err := clientFromCLI(c)
+			if err != nil {
+				return err
+			}
+
+			id := c.Uint("id")
+			results, err := client.ReplayDeadLetterWebhooks(id)
+			if err != nil {
+				return fmt.Errorf("replay dead-letter webhooks: %w", err)
+			}
+
+			for _, r := range results {
+				status := "ok"
+				if r.Err != "" {
+					status = r.Err
+				}
+				fmt.Fprintf(c.App.Writer, "%d\t%s\t%s\n", r.ID, r.URL, status)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,198 @@
+// Package inmem is a minimal, self-contained implementation of Fleet's
+// persistence interfaces for deployments (and this tree's `fleet serve`)
+// that want to exercise a feature without standing up the full
+// MySQL-backed datastore. Data is optionally seeded from a JSON file;
+// anything not read from the seed file lives only in memory and is lost on
+// restart.
+package inmem
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/fleetdm/fleet/v4/server/fleet"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// seedUser is the on-disk shape of a user entry in the seed file.
+// PasswordHash is a bcrypt hash, not a plaintext password, matching what
+// fleet.User itself stores.
+type seedUser struct {
+	ID           uint   `json:"id"`
+	Email        string `json:"email"`
+	PasswordHash string `json:"password_hash"`
+}
+
+type seedFile struct {
+	Users    []seedUser     `json:"users"`
+	Policies []fleet.Policy `json:"policies"`
+}
+
+// Datastore is an in-memory fleet.Datastore, optionally seeded from a JSON
+// file of users and policies. Dead-lettered webhooks are not seedable;
+// they only ever come from real failed deliveries.
+type Datastore struct {
+	mu sync.Mutex
+
+	usersByEmail     map[string]*fleet.User
+	policiesByID     map[uint]*fleet.Policy
+	deadLetters      map[uint]fleet.WebhookDeadLetter
+	nextDeadLetterID uint
+
+	sessionsByRefreshToken map[string]fleet.Session
+	nextSessionID          uint
+}
+
+// New constructs a Datastore, optionally seeded from the JSON file at path.
+// An empty path yields an empty datastore: every lookup fails cleanly
+// (sql.ErrNoRows) rather than the server refusing to start.
+func New(path string) (*Datastore, error) {
+	ds := &Datastore{
+		usersByEmail:           map[string]*fleet.User{},
+		policiesByID:           map[uint]*fleet.Policy{},
+		deadLetters:            map[uint]fleet.WebhookDeadLetter{},
+		sessionsByRefreshToken: map[string]fleet.Session{},
+	}
+	if path == "" {
+		return ds, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open datastore seed file: %w", err)
+	}
+	defer f.Close()
+
+	var seed seedFile
+	if err := json.NewDecoder(f).Decode(&seed); err != nil {
+		return nil, fmt.Errorf("decode datastore seed file: %w", err)
+	}
+
+	for _, u := range seed.Users {
+		ds.usersByEmail[u.Email] = &fleet.User{ID: u.ID, Email: u.Email, PasswordHash: u.PasswordHash}
+	}
+	for i := range seed.Policies {
+		p := seed.Policies[i]
+		ds.policiesByID[p.ID] = &p
+	}
+
+	return ds, nil
+}
+
+// UserByEmail implements service.ClientCertificateUserStore (and, as one of
+// several fleet.Datastore methods, the full interface).
+func (d *Datastore) UserByEmail(email string) (*fleet.User, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	u, ok := d.usersByEmail[email]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return u, nil
+}
+
+func (d *Datastore) Policy(ctx context.Context, id uint) (*fleet.Policy, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	p, ok := d.policiesByID[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return p, nil
+}
+
+func (d *Datastore) NewWebhookDeadLetter(ctx context.Context, deadLetter fleet.WebhookDeadLetter) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.nextDeadLetterID++
+	deadLetter.ID = d.nextDeadLetterID
+	d.deadLetters[deadLetter.ID] = deadLetter
+	return nil
+}
+
+func (d *Datastore) ListWebhookDeadLetters(ctx context.Context, id *uint) ([]fleet.WebhookDeadLetter, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if id != nil {
+		dl, ok := d.deadLetters[*id]
+		if !ok {
+			return nil, sql.ErrNoRows
+		}
+		return []fleet.WebhookDeadLetter{dl}, nil
+	}
+
+	out := make([]fleet.WebhookDeadLetter, 0, len(d.deadLetters))
+	for _, dl := range d.deadLetters {
+		out = append(out, dl)
+	}
+	return out, nil
+}
+
+func (d *Datastore) DeleteWebhookDeadLetter(ctx context.Context, id uint) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.deadLetters[id]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(d.deadLetters, id)
+	return nil
+}
+
+func (d *Datastore) Authenticate(email, password string) (*fleet.User, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	u, ok := d.usersByEmail[email]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)); err != nil {
+		return nil, fmt.Errorf("invalid credentials")
+	}
+	return u, nil
+}
+
+func (d *Datastore) NewSession(ctx context.Context, session fleet.Session) (*fleet.Session, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.nextSessionID++
+	session.ID = d.nextSessionID
+	d.sessionsByRefreshToken[session.RefreshToken] = session
+	return &session, nil
+}
+
+func (d *Datastore) SessionByRefreshToken(ctx context.Context, refreshToken string) (*fleet.Session, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	s, ok := d.sessionsByRefreshToken[refreshToken]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &s, nil
+}
+
+// SaveSession persists session's rotated refresh token, removing whatever
+// token it previously had on record so the old one can't be replayed.
+func (d *Datastore) SaveSession(ctx context.Context, session *fleet.Session) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for token, s := range d.sessionsByRefreshToken {
+		if s.ID == session.ID {
+			delete(d.sessionsByRefreshToken, token)
+		}
+	}
+	d.sessionsByRefreshToken[session.RefreshToken] = *session
+	return nil
+}
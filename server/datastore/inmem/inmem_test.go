@@ -0,0 +1,141 @@
+package inmem
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fleetdm/fleet/v4/server/fleet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestNewEmptyDatastore(t *testing.T) {
+	ds, err := New("")
+	require.NoError(t, err)
+
+	_, err = ds.UserByEmail("nobody@example.com")
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+}
+
+func TestNewSeededDatastore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "seed.json")
+
+	b, err := json.Marshal(seedFile{Users: []seedUser{{ID: 1, Email: "admin@example.com"}}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, b, 0600))
+
+	ds, err := New(path)
+	require.NoError(t, err)
+
+	user, err := ds.UserByEmail("admin@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), user.ID)
+
+	_, err = ds.UserByEmail("nobody@example.com")
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+}
+
+func TestNewRejectsUnreadableSeedFile(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "does-not-exist.json"))
+	assert.Error(t, err)
+}
+
+func TestPolicyLookup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "seed.json")
+
+	b, err := json.Marshal(seedFile{Policies: []fleet.Policy{{ID: 7, Name: "Disk encryption enabled"}}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, b, 0600))
+
+	ds, err := New(path)
+	require.NoError(t, err)
+
+	p, err := ds.Policy(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, "Disk encryption enabled", p.Name)
+
+	_, err = ds.Policy(context.Background(), 8)
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+}
+
+func TestWebhookDeadLetterLifecycle(t *testing.T) {
+	ds, err := New("")
+	require.NoError(t, err)
+
+	require.NoError(t, ds.NewWebhookDeadLetter(context.Background(), fleet.WebhookDeadLetter{URL: "https://example.com/hook"}))
+	require.NoError(t, ds.NewWebhookDeadLetter(context.Background(), fleet.WebhookDeadLetter{URL: "https://example.com/hook2"}))
+
+	all, err := ds.ListWebhookDeadLetters(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	one, err := ds.ListWebhookDeadLetters(context.Background(), &all[0].ID)
+	require.NoError(t, err)
+	require.Len(t, one, 1)
+	assert.Equal(t, all[0].URL, one[0].URL)
+
+	require.NoError(t, ds.DeleteWebhookDeadLetter(context.Background(), all[0].ID))
+	_, err = ds.ListWebhookDeadLetters(context.Background(), &all[0].ID)
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+}
+
+func TestAuthenticate(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "seed.json")
+	b, err := json.Marshal(seedFile{Users: []seedUser{{ID: 1, Email: "admin@example.com", PasswordHash: string(hash)}}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, b, 0600))
+
+	ds, err := New(path)
+	require.NoError(t, err)
+
+	user, err := ds.Authenticate("admin@example.com", "correct-password")
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), user.ID)
+
+	_, err = ds.Authenticate("admin@example.com", "wrong-password")
+	assert.Error(t, err)
+
+	_, err = ds.Authenticate("nobody@example.com", "correct-password")
+	assert.Error(t, err)
+}
+
+func TestSessionLifecycle(t *testing.T) {
+	ds, err := New("")
+	require.NoError(t, err)
+
+	session, err := ds.NewSession(context.Background(), fleet.Session{
+		UserID:                1,
+		RefreshToken:          "initial-refresh",
+		RefreshTokenExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, session.ID)
+
+	found, err := ds.SessionByRefreshToken(context.Background(), "initial-refresh")
+	require.NoError(t, err)
+	assert.Equal(t, session.ID, found.ID)
+	assert.Equal(t, uint(1), found.UserID)
+
+	found.RefreshToken = "rotated-refresh"
+	require.NoError(t, ds.SaveSession(context.Background(), found))
+
+	_, err = ds.SessionByRefreshToken(context.Background(), "initial-refresh")
+	assert.True(t, errors.Is(err, sql.ErrNoRows), "rotating a session's refresh token should invalidate the old one")
+
+	rotated, err := ds.SessionByRefreshToken(context.Background(), "rotated-refresh")
+	require.NoError(t, err)
+	assert.Equal(t, session.ID, rotated.ID)
+}
@@ -0,0 +1,51 @@
+package fleet
+
+import "time"
+
+// AppConfig holds Fleet's server-wide configuration.
+type AppConfig struct {
+	ServerSettings  ServerSettings  `json:"server_settings"`
+	WebhookSettings WebhookSettings `json:"webhook_settings"`
+}
+
+// ServerSettings holds settings describing the Fleet server itself.
+type ServerSettings struct {
+	// ServerURL is the externally-reachable base URL of this Fleet server,
+	// used to build deep links (e.g. into a policy or host) in outgoing
+	// notifications.
+	ServerURL string `json:"server_url"`
+}
+
+// WebhookSettings groups Fleet's outgoing webhook configuration.
+type WebhookSettings struct {
+	FailingPoliciesWebhook FailingPoliciesWebhookSettings `json:"failing_policies_webhook"`
+}
+
+// FailingPoliciesWebhookSettings configures the failing-policies webhook:
+// which policies to watch and where (and how) to deliver notifications
+// about their failing hosts.
+type FailingPoliciesWebhookSettings struct {
+	Enable         bool   `json:"enable"`
+	PolicyIDs      []uint `json:"policy_ids"`
+	DestinationURL string `json:"destination_url"`
+
+	// Secret signs each delivery's body with HMAC-SHA256, sent as the
+	// X-Fleet-Signature header, so receivers can verify a notification
+	// actually came from this Fleet server.
+	Secret string `json:"secret"`
+
+	// MaxAttempts overrides the default number of delivery attempts before
+	// a notification is dead-lettered. Zero means use the package default.
+	MaxAttempts int `json:"max_attempts"`
+
+	// Deadline overrides the default total time budget (across all
+	// attempts) before a notification is dead-lettered. Zero means use the
+	// package default.
+	Deadline time.Duration `json:"deadline"`
+
+	// Targets configures delivery to one or more pluggable destinations
+	// (Slack, PagerDuty, JIRA, or a generic JSON POST). When empty, a
+	// single required generic target is built from DestinationURL/Secret
+	// above so existing configs keep working unchanged.
+	Targets []WebhookTarget `json:"targets"`
+}
@@ -0,0 +1,44 @@
+package fleet
+
+import "context"
+
+// Datastore is Fleet's persistence interface. Only the subset of methods
+// actually called by this package is declared here.
+type Datastore interface {
+	// UserByEmail looks up a user by their email address. It returns an
+	// error if no such user exists.
+	UserByEmail(email string) (*User, error)
+
+	// Policy looks up a policy by ID. It returns sql.ErrNoRows if the
+	// policy has since been deleted.
+	Policy(ctx context.Context, id uint) (*Policy, error)
+
+	// NewWebhookDeadLetter persists a webhook delivery that exhausted its
+	// retry attempts, so it can later be listed or replayed.
+	NewWebhookDeadLetter(ctx context.Context, deadLetter WebhookDeadLetter) error
+
+	// ListWebhookDeadLetters returns dead-lettered webhook deliveries,
+	// optionally filtered down to a single entry by id.
+	ListWebhookDeadLetters(ctx context.Context, id *uint) ([]WebhookDeadLetter, error)
+
+	// DeleteWebhookDeadLetter removes a dead-lettered webhook delivery,
+	// e.g. after it has been successfully replayed.
+	DeleteWebhookDeadLetter(ctx context.Context, id uint) error
+
+	// Authenticate verifies email/password against the stored user and
+	// password hash. It returns an error if the email is unknown or the
+	// password doesn't match.
+	Authenticate(email, password string) (*User, error)
+
+	// NewSession persists a freshly logged-in session's refresh token
+	// state.
+	NewSession(ctx context.Context, session Session) (*Session, error)
+
+	// SessionByRefreshToken looks up the session a refresh token belongs
+	// to. It returns sql.ErrNoRows if the token is unknown or has already
+	// been rotated away by a previous refresh.
+	SessionByRefreshToken(ctx context.Context, refreshToken string) (*Session, error)
+
+	// SaveSession persists a session's rotated refresh token.
+	SaveSession(ctx context.Context, session *Session) error
+}
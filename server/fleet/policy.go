@@ -0,0 +1,8 @@
+package fleet
+
+// Policy is a Fleet policy: a saved osquery query whose results determine
+// whether a host passes or fails, e.g. "disk encryption enabled".
+type Policy struct {
+	ID   uint   `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+}
@@ -0,0 +1,20 @@
+package fleet
+
+import "time"
+
+// Session is a logged-in user's refresh-token state. The access token
+// handed out alongside a Session is not itself tracked server-side, the
+// same way the rest of the Fleet API's bearer tokens aren't: only the
+// longer-lived refresh token, which can mint new access tokens via
+// `/api/latest/fleet/token/refresh`, needs to be revocable.
+type Session struct {
+	ID     uint `json:"id" db:"id"`
+	UserID uint `json:"user_id" db:"user_id"`
+
+	// RefreshToken is the opaque token traded in at
+	// /api/latest/fleet/token/refresh for a new access/refresh token pair.
+	// Each use rotates it, invalidating the one it replaces.
+	RefreshToken string `json:"-" db:"refresh_token"`
+
+	RefreshTokenExpiresAt time.Time `json:"-" db:"refresh_token_expires_at"`
+}
@@ -0,0 +1,11 @@
+package fleet
+
+// User is a Fleet user account.
+type User struct {
+	ID    uint   `json:"id" db:"id"`
+	Email string `json:"email" db:"email"`
+	// PasswordHash is a bcrypt hash, never the plaintext password. It is
+	// excluded from JSON so a User never round-trips through an API
+	// response with it attached.
+	PasswordHash string `json:"-" db:"password_hash"`
+}
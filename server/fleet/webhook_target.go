@@ -0,0 +1,31 @@
+package fleet
+
+import "encoding/json"
+
+// WebhookTargetKind identifies which Notifier implementation a
+// WebhookTarget's Config decodes into.
+type WebhookTargetKind string
+
+const (
+	WebhookTargetGeneric   WebhookTargetKind = "generic"
+	WebhookTargetSlack     WebhookTargetKind = "slack"
+	WebhookTargetPagerDuty WebhookTargetKind = "pagerduty"
+	WebhookTargetJira      WebhookTargetKind = "jira"
+)
+
+// WebhookTarget is one destination a failing-policies notification is sent
+// to. Config is kind-specific (e.g. a Slack incoming webhook URL, or a JIRA
+// project/credentials) and is left as raw JSON here, rather than typed per
+// kind, so that this package doesn't need to import server/webhooks (which
+// already imports server/fleet for AppConfig/Datastore) to decode it.
+type WebhookTarget struct {
+	Kind WebhookTargetKind `json:"kind"`
+
+	// Required marks this target as one that must acknowledge delivery
+	// before a policy's failing hosts are removed from the failing set.
+	// Non-required targets are best-effort: their failure is dead-lettered
+	// but doesn't hold back the others.
+	Required bool `json:"required"`
+
+	Config json.RawMessage `json:"config"`
+}
@@ -0,0 +1,22 @@
+package fleet
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookDeadLetter is a webhook delivery that exhausted all of its retry
+// attempts. It is persisted so operators can inspect or replay it later via
+// `fleetctl webhooks replay`, instead of the event being silently dropped.
+// Kind/Config mirror the WebhookTarget the delivery was destined for, so a
+// replay can rebuild the same per-kind auth (e.g. JIRA basic auth, an HMAC
+// secret) instead of re-POSTing the raw payload unauthenticated.
+type WebhookDeadLetter struct {
+	ID        uint              `json:"id" db:"id"`
+	URL       string            `json:"url" db:"url"`
+	Kind      WebhookTargetKind `json:"kind" db:"kind"`
+	Config    json.RawMessage   `json:"config" db:"config"`
+	Payload   []byte            `json:"payload" db:"payload"`
+	Error     string            `json:"error" db:"error"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fleetdm/fleet/v4/pkg/fleethttp"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// PostJSONWithTimeout POSTs the JSON encoding of v to url, attaching the
+// given extra headers (e.g. webhook signing headers), and returns an error
+// if the request could not be completed or the server did not respond with
+// a 2xx status. It makes a single attempt; callers that need retries (e.g.
+// webhook delivery) are expected to loop and inspect the returned
+// *HTTPResponseError's Temporary method.
+func PostJSONWithTimeout(ctx context.Context, url string, v interface{}, headers map[string]string) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal json: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	client := fleethttp.NewClient(fleethttp.WithTimeout(defaultTimeout))
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &HTTPResponseError{Status: resp.StatusCode, RetryAfter: resp.Header.Get("Retry-After")}
+	}
+
+	return nil
+}
+
+// HTTPResponseError carries the status code of a non-2xx HTTP response so
+// that callers can distinguish transient failures (5xx, 429) from permanent
+// ones (4xx other than 429) without re-parsing the error string.
+type HTTPResponseError struct {
+	Status     int
+	RetryAfter string
+}
+
+func (e *HTTPResponseError) Error() string {
+	return fmt.Sprintf("unexpected status code %d", e.Status)
+}
+
+// Temporary reports whether the response indicates the delivery should be
+// retried: any 5xx status, or 429 Too Many Requests.
+func (e *HTTPResponseError) Temporary() bool {
+	return e.Status >= 500 || e.Status == http.StatusTooManyRequests
+}
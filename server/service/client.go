@@ -0,0 +1,194 @@
+package service
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrUnauthenticated is returned when a request fails because the
+// configured token (or certificate) was rejected by the server.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// ClientOption configures a Client at construction time.
+type ClientOption func(*Client) error
+
+// Client is an API client for the Fleet server, used by fleetctl and other
+// first-party tooling.
+type Client struct {
+	baseURL   *url.URL
+	urlPrefix string
+	token     string
+
+	http      *http.Client
+	tlsConfig *tls.Config
+
+	writer io.Writer
+	debug  bool
+}
+
+// NewClient creates a Client pointed at addr, verifying the server's
+// certificate against rootCA (a PEM file path) when set. Transport and TLS
+// settings can be further customized with opts, e.g. WithCustomTransport or
+// WithClientCertificate.
+func NewClient(addr string, insecureSkipVerify bool, rootCA, urlPrefix string, opts ...ClientOption) (*Client, error) {
+	baseURL, err := url.Parse(addr)
+	if err != nil {
+		return nil, fmt.Errorf("parse address: %w", err)
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+	if rootCA != "" {
+		pemBytes, err := ioutil.ReadFile(rootCA)
+		if err != nil {
+			return nil, fmt.Errorf("reading root CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if ok := pool.AppendCertsFromPEM(pemBytes); !ok {
+			return nil, errors.New("failed to add certificates to root CA pool")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	c := &Client{
+		baseURL:   baseURL,
+		urlPrefix: urlPrefix,
+		tlsConfig: tlsConfig,
+		http:      &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}},
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// SetClientWriter sets the writer used for verbose/debug output.
+func SetClientWriter(w io.Writer) ClientOption {
+	return func(c *Client) error {
+		c.writer = w
+		return nil
+	}
+}
+
+// EnableClientDebug turns on verbose request/response logging to the
+// client's writer.
+func EnableClientDebug() ClientOption {
+	return func(c *Client) error {
+		c.debug = true
+		return nil
+	}
+}
+
+// WithCustomTransport overrides the client's HTTP transport wholesale, e.g.
+// to dial a UNIX domain socket instead of a network address. Since it
+// replaces the transport entirely, it supersedes any TLS settings NewClient
+// or WithClientCertificate configured.
+func WithCustomTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) error {
+		c.http.Transport = rt
+		return nil
+	}
+}
+
+// SetToken sets the bearer token attached to subsequent requests.
+func (c *Client) SetToken(t string) {
+	c.token = t
+}
+
+// Transport returns the client's current HTTP transport, so callers can
+// wrap it (e.g. to add transparent token refresh) without discarding
+// whatever transport NewClient/WithCustomTransport/WithClientCertificate
+// configured.
+func (c *Client) Transport() http.RoundTripper {
+	return c.http.Transport
+}
+
+// SetTransport overrides the client's HTTP transport after construction.
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.http.Transport = rt
+}
+
+func (c *Client) urlFor(path string) *url.URL {
+	u := *c.baseURL
+	path, query := path, ""
+	if i := strings.IndexByte(path, '?'); i != -1 {
+		path, query = path[:i], path[i+1:]
+	}
+	u.Path = strings.TrimRight(c.urlPrefix, "/") + path
+	u.RawQuery = query
+	return &u
+}
+
+func (c *Client) request(method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request body: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, c.urlFor(path).String(), reqBody)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if c.debug && c.writer != nil {
+		fmt.Fprintf(c.writer, "%s %s -> %d\n", method, path, resp.StatusCode)
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthenticated
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d from %s %s", resp.StatusCode, method, path)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// VersionInfo is the subset of the server's version response that fleetctl
+// checks against its own build.
+type VersionInfo struct {
+	Version string `json:"version"`
+}
+
+// Version fetches the server's version info. Besides the version check
+// itself, calling this also exercises authentication: an expired or
+// invalid token surfaces as ErrUnauthenticated.
+func (c *Client) Version() (*VersionInfo, error) {
+	var resp VersionInfo
+	if err := c.request(http.MethodGet, "/api/latest/fleet/version", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
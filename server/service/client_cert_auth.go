@@ -0,0 +1,59 @@
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/fleetdm/fleet/v4/server/fleet"
+)
+
+// WithClientCertificate configures the client to present the given
+// certificate for mutual TLS. It is the programmatic equivalent of setting
+// tls_cert/tls_key on a fleetctl context, for callers that already hold the
+// certificate in memory (e.g. loaded from a PKI-managed secret store)
+// instead of a file on disk.
+func WithClientCertificate(cert tls.Certificate) ClientOption {
+	return func(c *Client) error {
+		if c.tlsConfig == nil {
+			return fmt.Errorf("client has no TLS config to attach a certificate to")
+		}
+		c.tlsConfig.Certificates = []tls.Certificate{cert}
+		return nil
+	}
+}
+
+// ClientCertificateUserStore is the minimal persistence dependency client
+// certificate authentication needs: mapping a trusted email/CN to a Fleet
+// user. It is declared narrowly, rather than as fleet.Datastore, so that
+// resolving certificate identities doesn't require a full datastore
+// implementation (policies, webhook dead-letters, etc.) that mTLS has no
+// use for.
+type ClientCertificateUserStore interface {
+	UserByEmail(email string) (*fleet.User, error)
+}
+
+// userFromClientCertificate maps an already-verified client certificate to
+// the Fleet user it authenticates as. The server only calls this once the
+// TLS handshake has verified the certificate chain against the configured
+// client CA, so the CN/SAN here are trusted identifiers, not user input.
+//
+// Resolution order mirrors how Fleet already resolves identity from other
+// out-of-band sources (e.g. SSO assertions): SAN email addresses are tried
+// first since they map directly onto fleet.User.Email, falling back to the
+// certificate's CN treated as an email.
+func userFromClientCertificate(ds ClientCertificateUserStore, cert *x509.Certificate) (*fleet.User, error) {
+	candidates := append([]string{}, cert.EmailAddresses...)
+	if cert.Subject.CommonName != "" {
+		candidates = append(candidates, cert.Subject.CommonName)
+	}
+
+	for _, email := range candidates {
+		user, err := ds.UserByEmail(email)
+		if err == nil {
+			return user, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no Fleet user matches client certificate %q", cert.Subject.CommonName)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"testing"
+
+	"github.com/fleetdm/fleet/v4/server/fleet"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDatastore struct {
+	usersByEmail map[string]*fleet.User
+}
+
+func (d *fakeDatastore) UserByEmail(email string) (*fleet.User, error) {
+	u, ok := d.usersByEmail[email]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return u, nil
+}
+
+func (d *fakeDatastore) Policy(ctx context.Context, id uint) (*fleet.Policy, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (d *fakeDatastore) NewWebhookDeadLetter(ctx context.Context, deadLetter fleet.WebhookDeadLetter) error {
+	return errors.New("not implemented")
+}
+
+func (d *fakeDatastore) ListWebhookDeadLetters(ctx context.Context, id *uint) ([]fleet.WebhookDeadLetter, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (d *fakeDatastore) DeleteWebhookDeadLetter(ctx context.Context, id uint) error {
+	return errors.New("not implemented")
+}
+
+func TestUserFromClientCertificate(t *testing.T) {
+	ds := &fakeDatastore{usersByEmail: map[string]*fleet.User{
+		"san@example.com": {ID: 1, Email: "san@example.com"},
+		"cn@example.com":  {ID: 2, Email: "cn@example.com"},
+	}}
+
+	t.Run("matches SAN email", func(t *testing.T) {
+		cert := &x509.Certificate{EmailAddresses: []string{"san@example.com"}}
+		user, err := userFromClientCertificate(ds, cert)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), user.ID)
+	})
+
+	t.Run("falls back to CN", func(t *testing.T) {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: "cn@example.com"}}
+		user, err := userFromClientCertificate(ds, cert)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), user.ID)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: "nobody@example.com"}}
+		_, err := userFromClientCertificate(ds, cert)
+		assert.Error(t, err)
+	})
+}
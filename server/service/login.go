@@ -0,0 +1,26 @@
+package service
+
+import "net/http"
+
+// LoginResult is the access/refresh token pair minted by a successful
+// login.
+type LoginResult struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// Login authenticates email/password against the Fleet server and returns
+// the minted access/refresh token pair.
+func (c *Client) Login(email, password string) (*LoginResult, error) {
+	req := struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}{Email: email, Password: password}
+
+	var resp LoginResult
+	if err := c.request(http.MethodPost, "/api/latest/fleet/login", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
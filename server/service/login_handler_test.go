@@ -0,0 +1,68 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/fleetdm/fleet/v4/server/datastore/inmem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newSeededDatastore(t *testing.T, email, password string) *inmem.Datastore {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	path := dir + "/seed.json"
+	b, err := json.Marshal(map[string]interface{}{
+		"users": []map[string]interface{}{
+			{"id": 1, "email": email, "password_hash": string(hash)},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, b, 0600))
+
+	ds, err := inmem.New(path)
+	require.NoError(t, err)
+	return ds
+}
+
+func TestLoginHandlerSuccess(t *testing.T) {
+	ds := newSeededDatastore(t, "admin@example.com", "correct-password")
+
+	body, err := json.Marshal(loginRequest{Email: "admin@example.com", Password: "correct-password"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/latest/fleet/login", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	LoginHandler(ds).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp loginResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.NotEmpty(t, resp.RefreshToken)
+	assert.Equal(t, int64(accessTokenTTL.Seconds()), resp.ExpiresIn)
+}
+
+func TestLoginHandlerRejectsBadCredentials(t *testing.T) {
+	ds := newSeededDatastore(t, "admin@example.com", "correct-password")
+
+	body, err := json.Marshal(loginRequest{Email: "admin@example.com", Password: "wrong-password"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/latest/fleet/login", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	LoginHandler(ds).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/fleetdm/fleet/v4/server/fleet"
+)
+
+const (
+	// accessTokenTTL is how long a minted access token is valid before the
+	// client must use its refresh token to obtain a new one.
+	accessTokenTTL = time.Hour
+
+	// refreshTokenTTL is how long a refresh token is valid before the user
+	// must `fleetctl login` again. It is much longer-lived than the access
+	// token since it's only ever sent to /api/latest/fleet/token/refresh,
+	// never attached to ordinary API requests.
+	refreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// TokenPair is the access/refresh token pair minted on login and rotated on
+// every refresh.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64
+}
+
+// generateToken returns an opaque, cryptographically random token suitable
+// for use as either an access or refresh token.
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// mintTokenPair issues a fresh access/refresh token pair for userID,
+// persisting the refresh token as a new session so a later call to
+// TokenRefreshHandler can find it.
+func mintTokenPair(ctx context.Context, ds fleet.Datastore, userID uint) (TokenPair, error) {
+	accessToken, err := generateToken()
+	if err != nil {
+		return TokenPair{}, err
+	}
+	refreshToken, err := generateToken()
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	if _, err := ds.NewSession(ctx, fleet.Session{
+		UserID:                userID,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: time.Now().Add(refreshTokenTTL),
+	}); err != nil {
+		return TokenPair{}, fmt.Errorf("persist session: %w", err)
+	}
+
+	return TokenPair{AccessToken: accessToken, RefreshToken: refreshToken, ExpiresIn: int64(accessTokenTTL.Seconds())}, nil
+}
+
+// rotateTokenPair issues a new access/refresh token pair for an existing
+// session, invalidating its current refresh token so each one is usable
+// exactly once.
+func rotateTokenPair(ctx context.Context, ds fleet.Datastore, session *fleet.Session) (TokenPair, error) {
+	accessToken, err := generateToken()
+	if err != nil {
+		return TokenPair{}, err
+	}
+	refreshToken, err := generateToken()
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	session.RefreshToken = refreshToken
+	session.RefreshTokenExpiresAt = time.Now().Add(refreshTokenTTL)
+	if err := ds.SaveSession(ctx, session); err != nil {
+		return TokenPair{}, fmt.Errorf("persist rotated session: %w", err)
+	}
+
+	return TokenPair{AccessToken: accessToken, RefreshToken: refreshToken, ExpiresIn: int64(accessTokenTTL.Seconds())}, nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/fleetdm/fleet/v4/server/fleet"
+)
+
+type tokenRefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type tokenRefreshResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// TokenRefreshHandler serves POST /api/latest/fleet/token/refresh, the
+// endpoint fleetctl's refreshingTransport calls to mint a new access token
+// without requiring the user to `fleetctl login` again. The presented
+// refresh token is rotated: it is invalidated in favor of a new one
+// returned alongside the new access token, so a refresh token is usable
+// exactly once.
+func TokenRefreshHandler(ds fleet.Datastore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req tokenRefreshRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		session, err := ds.SessionByRefreshToken(r.Context(), req.RefreshToken)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			http.Error(w, "invalid refresh token", http.StatusUnauthorized)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if time.Now().After(session.RefreshTokenExpiresAt) {
+			http.Error(w, "refresh token expired", http.StatusUnauthorized)
+			return
+		}
+
+		pair, err := rotateTokenPair(r.Context(), ds, session)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(tokenRefreshResponse{
+			AccessToken:  pair.AccessToken,
+			RefreshToken: pair.RefreshToken,
+			ExpiresIn:    pair.ExpiresIn,
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenRefreshHandlerRotatesToken(t *testing.T) {
+	ds := newSeededDatastore(t, "admin@example.com", "correct-password")
+
+	loginBody, err := json.Marshal(loginRequest{Email: "admin@example.com", Password: "correct-password"})
+	require.NoError(t, err)
+	loginReq := httptest.NewRequest(http.MethodPost, "/api/latest/fleet/login", bytes.NewReader(loginBody))
+	loginW := httptest.NewRecorder()
+	LoginHandler(ds).ServeHTTP(loginW, loginReq)
+	require.Equal(t, http.StatusOK, loginW.Code)
+
+	var login loginResponse
+	require.NoError(t, json.NewDecoder(loginW.Body).Decode(&login))
+
+	refreshBody, err := json.Marshal(tokenRefreshRequest{RefreshToken: login.RefreshToken})
+	require.NoError(t, err)
+	refreshReq := httptest.NewRequest(http.MethodPost, "/api/latest/fleet/token/refresh", bytes.NewReader(refreshBody))
+	refreshW := httptest.NewRecorder()
+	TokenRefreshHandler(ds).ServeHTTP(refreshW, refreshReq)
+
+	require.Equal(t, http.StatusOK, refreshW.Code)
+
+	var refreshed tokenRefreshResponse
+	require.NoError(t, json.NewDecoder(refreshW.Body).Decode(&refreshed))
+	assert.NotEmpty(t, refreshed.AccessToken)
+	assert.NotEqual(t, login.RefreshToken, refreshed.RefreshToken)
+
+	// The refresh token from login was rotated away, so using it again fails.
+	replayReq := httptest.NewRequest(http.MethodPost, "/api/latest/fleet/token/refresh", bytes.NewReader(refreshBody))
+	replayW := httptest.NewRecorder()
+	TokenRefreshHandler(ds).ServeHTTP(replayW, replayReq)
+	assert.Equal(t, http.StatusUnauthorized, replayW.Code)
+}
+
+func TestTokenRefreshHandlerRejectsUnknownToken(t *testing.T) {
+	ds := newSeededDatastore(t, "admin@example.com", "correct-password")
+
+	body, err := json.Marshal(tokenRefreshRequest{RefreshToken: "bogus"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/latest/fleet/token/refresh", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	TokenRefreshHandler(ds).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// WebhookReplayResult reports the outcome of re-sending a single
+// dead-lettered webhook delivery.
+type WebhookReplayResult struct {
+	ID  uint   `json:"id"`
+	URL string `json:"url"`
+	// Err is the delivery error, if replay failed. Empty means the replay
+	// succeeded and the dead-letter entry was removed.
+	Err string `json:"error,omitempty"`
+}
+
+// ReplayDeadLetterWebhooks asks the server to re-attempt delivery of
+// dead-lettered webhook(s). When id is zero, every dead-lettered delivery is
+// replayed; otherwise only the entry with that ID is.
+func (c *Client) ReplayDeadLetterWebhooks(id uint) ([]WebhookReplayResult, error) {
+	path := "/api/latest/fleet/webhooks/dead_letter/replay"
+	if id != 0 {
+		path += "?id=" + strconv.FormatUint(uint64(id), 10)
+	}
+
+	var resp struct {
+		Results []WebhookReplayResult `json:"results"`
+	}
+	if err := c.request(http.MethodPost, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
@@ -0,0 +1,197 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/fleetdm/fleet/v4/server"
+	"github.com/fleetdm/fleet/v4/server/contexts/ctxerr"
+	"github.com/fleetdm/fleet/v4/server/fleet"
+)
+
+const (
+	defaultMaxDeliveryAttempts = 6
+	defaultDeliveryDeadline    = 5 * time.Minute
+	baseBackoff                = 500 * time.Millisecond
+	maxBackoff                 = 30 * time.Second
+)
+
+// deliveryLimitsContextKey is the context.Context key used to carry
+// per-AppConfig delivery limits down to deliverWebhook, since retries are
+// configurable (FailingPoliciesWebhookSettings.MaxAttempts/Deadline) but
+// threading them through the Notifier interface would mean every notifier
+// implementation has to plumb limits it doesn't otherwise care about.
+type deliveryLimitsContextKey struct{}
+
+type deliveryLimits struct {
+	maxAttempts int
+	deadline    time.Duration
+}
+
+// withDeliveryLimits attaches maxAttempts/deadline overrides to ctx for
+// deliverWebhook to pick up. A zero value for either means "use the
+// package default".
+func withDeliveryLimits(ctx context.Context, maxAttempts int, deadline time.Duration) context.Context {
+	return context.WithValue(ctx, deliveryLimitsContextKey{}, deliveryLimits{
+		maxAttempts: maxAttempts,
+		deadline:    deadline,
+	})
+}
+
+func deliveryLimitsFromContext(ctx context.Context) (maxAttempts int, deadline time.Duration) {
+	maxAttempts, deadline = defaultMaxDeliveryAttempts, defaultDeliveryDeadline
+	limits, ok := ctx.Value(deliveryLimitsContextKey{}).(deliveryLimits)
+	if !ok {
+		return maxAttempts, deadline
+	}
+	if limits.maxAttempts > 0 {
+		maxAttempts = limits.maxAttempts
+	}
+	if limits.deadline > 0 {
+		deadline = limits.deadline
+	}
+	return maxAttempts, deadline
+}
+
+// signPayload returns the hex-encoded HMAC-SHA256 of body using secret,
+// bound to timestamp so that a captured signature can't be replayed against
+// a different delivery. Receivers recompute the same digest over
+// "<timestamp>.<body>" and compare it to the X-Fleet-Signature header.
+func signPayload(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliverWebhook POSTs payload to url, signing it with secret (when set)
+// and retrying transient failures with jittered exponential backoff until
+// a 2xx response is observed, the maximum number of attempts is reached, or
+// the total delivery deadline would be exceeded (both taken from
+// deliveryLimitsFromContext). It returns the last error seen on permanent
+// failure, or ctx's error if ctx is done while waiting between attempts.
+func deliverWebhook(ctx context.Context, url, secret string, payload interface{}) error {
+	return deliverWebhookWithHeaders(ctx, url, secret, payload, nil)
+}
+
+// deliverWebhookWithHeaders is deliverWebhook plus caller-supplied headers
+// (e.g. third-party destination auth), merged in on every attempt alongside
+// the signing headers.
+func deliverWebhookWithHeaders(ctx context.Context, url, secret string, payload interface{}, extraHeaders map[string]string) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	maxAttempts, deliveryDeadline := deliveryLimitsFromContext(ctx)
+	deadline := time.Now().Add(deliveryDeadline)
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			wait := backoff(attempt)
+			if time.Now().Add(wait).After(deadline) {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		headers := map[string]string{"X-Fleet-Timestamp": timestamp}
+		for k, v := range extraHeaders {
+			headers[k] = v
+		}
+		if secret != "" {
+			headers["X-Fleet-Signature"] = "sha256=" + signPayload(secret, timestamp, body)
+		}
+
+		lastErr = server.PostJSONWithTimeout(ctx, url, payload, headers)
+		if lastErr == nil {
+			return nil
+		}
+
+		var respErr *server.HTTPResponseError
+		if errors.As(lastErr, &respErr) {
+			if !respErr.Temporary() {
+				return lastErr
+			}
+			if wait, ok := retryAfter(respErr.RetryAfter); ok {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(wait):
+				}
+			}
+			continue
+		}
+
+		// Connection-level errors are treated as transient and retried.
+	}
+
+	return lastErr
+}
+
+// backoff returns a jittered exponential delay for the given attempt
+// number (1-indexed), capped at maxBackoff.
+func backoff(attempt int) time.Duration {
+	d := time.Duration(float64(baseBackoff) * math.Pow(2, float64(attempt-1)))
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	jitter, err := rand.Int(rand.Reader, big.NewInt(int64(d/2)+1))
+	if err != nil {
+		return d
+	}
+	return d/2 + time.Duration(jitter.Int64())
+}
+
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	secs, err := strconv.Atoi(header)
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+	return time.Duration(secs) * time.Second, true
+}
+
+// deadLetterWebhook persists a payload that exhausted all delivery
+// attempts so operators can inspect or replay it later via
+// `fleetctl webhooks replay`. target's Kind/Config are persisted alongside
+// the payload so a replay can rebuild the same destination-specific
+// Notifier rather than re-POSTing the raw payload unauthenticated.
+func deadLetterWebhook(ctx context.Context, ds fleet.Datastore, target fleet.WebhookTarget, url string, payload interface{}, deliveryErr error) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload for dead-letter: %w", err)
+	}
+
+	if err := ds.NewWebhookDeadLetter(ctx, fleet.WebhookDeadLetter{
+		URL:       url,
+		Kind:      target.Kind,
+		Config:    target.Config,
+		Payload:   body,
+		Error:     deliveryErr.Error(),
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return ctxerr.Wrapf(ctx, err, "recording dead-letter webhook for '%s'", url)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignPayload(t *testing.T) {
+	body := []byte(`{"hello":"world"}`)
+	sig := signPayload("shared-secret", "1234567890", body)
+
+	mac := hmac.New(sha256.New, []byte("shared-secret"))
+	mac.Write([]byte("1234567890"))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	want := hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, want, sig)
+	assert.Len(t, sig, sha256.Size*2, "hex-encoded sha256 digest should be 64 characters")
+}
+
+func TestBackoffBounds(t *testing.T) {
+	for attempt := 1; attempt <= 10; attempt++ {
+		d := backoff(attempt)
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.LessOrEqual(t, d, maxBackoff)
+	}
+}
+
+func TestDeliveryLimitsFromContext(t *testing.T) {
+	maxAttempts, deadline := deliveryLimitsFromContext(context.Background())
+	assert.Equal(t, defaultMaxDeliveryAttempts, maxAttempts)
+	assert.Equal(t, defaultDeliveryDeadline, deadline)
+
+	ctx := withDeliveryLimits(context.Background(), 3, 10*time.Second)
+	maxAttempts, deadline = deliveryLimitsFromContext(ctx)
+	assert.Equal(t, 3, maxAttempts)
+	assert.Equal(t, 10*time.Second, deadline)
+
+	// Zero overrides fall back to the package defaults.
+	ctx = withDeliveryLimits(context.Background(), 0, 0)
+	maxAttempts, deadline = deliveryLimitsFromContext(ctx)
+	assert.Equal(t, defaultMaxDeliveryAttempts, maxAttempts)
+	assert.Equal(t, defaultDeliveryDeadline, deadline)
+}
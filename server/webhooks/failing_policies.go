@@ -8,7 +8,6 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/fleetdm/fleet/v4/server"
 	"github.com/fleetdm/fleet/v4/server/contexts/ctxerr"
 	"github.com/fleetdm/fleet/v4/server/fleet"
 	"github.com/fleetdm/fleet/v4/server/service"
@@ -30,6 +29,9 @@ func TriggerFailingPoliciesWebhook(
 
 	level.Debug(logger).Log("enabled", "true")
 
+	webhookSettings := appConfig.WebhookSettings.FailingPoliciesWebhook
+	ctx = withDeliveryLimits(ctx, webhookSettings.MaxAttempts, webhookSettings.Deadline)
+
 	for _, policyID := range appConfig.WebhookSettings.FailingPoliciesWebhook.PolicyIDs {
 		policy, err := ds.Policy(ctx, policyID)
 		switch {
@@ -53,11 +55,30 @@ func TriggerFailingPoliciesWebhook(
 			Timestamp:    now,
 			Policy:       policy,
 			FailingHosts: failingHosts,
+			PolicyURL:    appConfig.ServerSettings.ServerURL + "/policies/" + strconv.Itoa(int(policyID)),
 		}
-		url := appConfig.WebhookSettings.FailingPoliciesWebhook.DestinationURL
-		err = server.PostJSONWithTimeout(ctx, url, &payload)
-		if err != nil {
-			return ctxerr.Wrapf(ctx, err, "posting to '%s'", url)
+		targets := webhookTargets(appConfig)
+		allRequiredDelivered := true
+		for _, target := range targets {
+			notifier, err := notifierForTarget(target)
+			if err != nil {
+				return ctxerr.Wrapf(ctx, err, "building notifier for policy %d", policyID)
+			}
+
+			destination := targetDestination(target)
+			if err := notifier.Notify(ctx, payload); err != nil {
+				level.Error(logger).Log("msg", "delivering failing policies notification, moving to dead-letter", "target", target.Kind, "err", err)
+				if dlErr := deadLetterWebhook(ctx, ds, target, destination, &payload, err); dlErr != nil {
+					return ctxerr.Wrapf(ctx, dlErr, "dead-lettering failed notification to '%s'", destination)
+				}
+				if target.Required {
+					allRequiredDelivered = false
+				}
+			}
+		}
+
+		if !allRequiredDelivered {
+			continue
 		}
 		if err := failingPoliciesSet.RemoveHosts(policyID, hosts); err != nil {
 			return ctxerr.Wrapf(ctx, err, "removing hosts %+v from failing policies set %d", hosts, policyID)
@@ -70,6 +91,10 @@ type FailingPoliciesPayload struct {
 	Timestamp    time.Time     `json:"timestamp"`
 	Policy       *fleet.Policy `json:"policy"`
 	FailingHosts []FailingHost `json:"hosts"`
+	// PolicyURL is a deep link to the policy in the Fleet UI, used by
+	// notifiers (e.g. Slack) that render a human-facing message rather
+	// than forwarding the raw JSON payload.
+	PolicyURL string `json:"policy_url"`
 }
 
 type FailingHost struct {
@@ -0,0 +1,21 @@
+package webhooks
+
+import "context"
+
+// GenericTargetConfig configures the original failing-policies webhook
+// behavior: a single JSON POST of FailingPoliciesPayload, HMAC-signed when
+// Secret is set.
+type GenericTargetConfig struct {
+	DestinationURL string `json:"destination_url"`
+	Secret         string `json:"secret"`
+}
+
+// GenericNotifier is the pre-existing JSON webhook behavior, expressed as a
+// Notifier so it composes with Slack/PagerDuty/JIRA targets.
+type GenericNotifier struct {
+	Config GenericTargetConfig
+}
+
+func (n *GenericNotifier) Notify(ctx context.Context, payload FailingPoliciesPayload) error {
+	return deliverWebhook(ctx, n.Config.DestinationURL, n.Config.Secret, &payload)
+}
@@ -0,0 +1,102 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// JiraTargetConfig configures delivery via the JIRA REST create-issue
+// endpoint. Username/APIToken authenticate with HTTP basic auth, which is
+// what JIRA Cloud's REST API expects for API token auth.
+type JiraTargetConfig struct {
+	ServerURL  string `json:"server_url"`
+	ProjectKey string `json:"project_key"`
+	IssueType  string `json:"issue_type"`
+	Username   string `json:"username"`
+	APIToken   string `json:"api_token"`
+}
+
+// JiraNotifier creates a JIRA issue describing a failing policy.
+type JiraNotifier struct {
+	Config JiraTargetConfig
+}
+
+func (n *JiraNotifier) Notify(ctx context.Context, payload FailingPoliciesPayload) error {
+	issueType := n.Config.IssueType
+	if issueType == "" {
+		issueType = "Task"
+	}
+
+	req := jiraCreateIssueRequest{
+		Fields: jiraIssueFields{
+			Project:     jiraProjectRef{Key: n.Config.ProjectKey},
+			IssueType:   jiraIssueTypeRef{Name: issueType},
+			Summary:     jiraSummary(payload),
+			Description: jiraDescription(payload),
+		},
+	}
+
+	issueURL, err := jiraIssueURL(n.Config.ServerURL)
+	if err != nil {
+		return fmt.Errorf("build jira issue URL: %w", err)
+	}
+	headers := map[string]string{"Authorization": "Basic " + basicAuth(n.Config.Username, n.Config.APIToken)}
+	return deliverWebhookWithHeaders(ctx, issueURL, "", &req, headers)
+}
+
+// jiraIssueURL builds the create-issue REST endpoint from serverURL.
+// serverURL may or may not include a scheme (e.g. both "foo.atlassian.net"
+// and "https://foo.atlassian.net" are accepted); defaulting to https when
+// absent. Parsing with url.Parse and appending to u.Path, rather than
+// path.Join on the whole string, avoids path.Clean collapsing the "//" in
+// "https://" when ServerURL is already a full URL.
+func jiraIssueURL(serverURL string) (string, error) {
+	if !strings.Contains(serverURL, "://") {
+		serverURL = "https://" + serverURL
+	}
+
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return "", err
+	}
+	u.Path = strings.TrimRight(u.Path, "/") + "/rest/api/2/issue"
+	return u.String(), nil
+}
+
+func basicAuth(username, token string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + token))
+}
+
+func jiraSummary(payload FailingPoliciesPayload) string {
+	policyName := "unknown policy"
+	if payload.Policy != nil {
+		policyName = payload.Policy.Name
+	}
+	return fmt.Sprintf("Fleet policy failing: %s", policyName)
+}
+
+func jiraDescription(payload FailingPoliciesPayload) string {
+	return fmt.Sprintf("%d host(s) are currently failing this policy. See %s for details.", len(payload.FailingHosts), payload.PolicyURL)
+}
+
+type jiraCreateIssueRequest struct {
+	Fields jiraIssueFields `json:"fields"`
+}
+
+type jiraIssueFields struct {
+	Project     jiraProjectRef   `json:"project"`
+	IssueType   jiraIssueTypeRef `json:"issuetype"`
+	Summary     string           `json:"summary"`
+	Description string           `json:"description"`
+}
+
+type jiraProjectRef struct {
+	Key string `json:"key"`
+}
+
+type jiraIssueTypeRef struct {
+	Name string `json:"name"`
+}
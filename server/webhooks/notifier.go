@@ -0,0 +1,99 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fleetdm/fleet/v4/server/fleet"
+)
+
+// Notifier delivers a failing-policies notification to one destination
+// kind. Implementations are responsible for rendering FailingPoliciesPayload
+// into whatever shape their destination expects.
+type Notifier interface {
+	Notify(ctx context.Context, payload FailingPoliciesPayload) error
+}
+
+// notifierForTarget decodes a fleet.WebhookTarget's kind-specific Config
+// into the matching Notifier implementation. The config is stored as raw
+// JSON on fleet.WebhookTarget (rather than a webhooks-package type) so that
+// server/fleet, which AppConfig lives in, doesn't need to import
+// server/webhooks.
+func notifierForTarget(t fleet.WebhookTarget) (Notifier, error) {
+	switch t.Kind {
+	case fleet.WebhookTargetGeneric:
+		var cfg GenericTargetConfig
+		if err := json.Unmarshal(t.Config, &cfg); err != nil {
+			return nil, fmt.Errorf("decode generic target config: %w", err)
+		}
+		return &GenericNotifier{Config: cfg}, nil
+	case fleet.WebhookTargetSlack:
+		var cfg SlackTargetConfig
+		if err := json.Unmarshal(t.Config, &cfg); err != nil {
+			return nil, fmt.Errorf("decode slack target config: %w", err)
+		}
+		return &SlackNotifier{Config: cfg}, nil
+	case fleet.WebhookTargetPagerDuty:
+		var cfg PagerDutyTargetConfig
+		if err := json.Unmarshal(t.Config, &cfg); err != nil {
+			return nil, fmt.Errorf("decode pagerduty target config: %w", err)
+		}
+		return &PagerDutyNotifier{Config: cfg}, nil
+	case fleet.WebhookTargetJira:
+		var cfg JiraTargetConfig
+		if err := json.Unmarshal(t.Config, &cfg); err != nil {
+			return nil, fmt.Errorf("decode jira target config: %w", err)
+		}
+		return &JiraNotifier{Config: cfg}, nil
+	default:
+		return nil, fmt.Errorf("unknown webhook target kind %q", t.Kind)
+	}
+}
+
+// webhookTargets returns the configured failing-policies targets, falling
+// back to a single required generic target built from the legacy
+// DestinationURL/Secret fields so existing configs keep working unchanged.
+func webhookTargets(appConfig *fleet.AppConfig) []fleet.WebhookTarget {
+	settings := appConfig.WebhookSettings.FailingPoliciesWebhook
+	if len(settings.Targets) > 0 {
+		return settings.Targets
+	}
+
+	cfg, _ := json.Marshal(GenericTargetConfig{
+		DestinationURL: settings.DestinationURL,
+		Secret:         settings.Secret,
+	})
+	return []fleet.WebhookTarget{
+		{
+			Kind:     fleet.WebhookTargetGeneric,
+			Required: true,
+			Config:   cfg,
+		},
+	}
+}
+
+// targetDestination returns a human-readable identifier for t, used for
+// logging and dead-letter records.
+func targetDestination(t fleet.WebhookTarget) string {
+	switch t.Kind {
+	case fleet.WebhookTargetGeneric:
+		var cfg GenericTargetConfig
+		if json.Unmarshal(t.Config, &cfg) == nil {
+			return cfg.DestinationURL
+		}
+	case fleet.WebhookTargetSlack:
+		var cfg SlackTargetConfig
+		if json.Unmarshal(t.Config, &cfg) == nil {
+			return cfg.WebhookURL
+		}
+	case fleet.WebhookTargetPagerDuty:
+		return "pagerduty"
+	case fleet.WebhookTargetJira:
+		var cfg JiraTargetConfig
+		if json.Unmarshal(t.Config, &cfg) == nil {
+			return cfg.ServerURL
+		}
+	}
+	return string(t.Kind)
+}
@@ -0,0 +1,91 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fleetdm/fleet/v4/server/fleet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookTargetsFallsBackToLegacyGenericTarget(t *testing.T) {
+	appConfig := &fleet.AppConfig{}
+	appConfig.WebhookSettings.FailingPoliciesWebhook.DestinationURL = "https://example.com/hook"
+	appConfig.WebhookSettings.FailingPoliciesWebhook.Secret = "shh"
+
+	targets := webhookTargets(appConfig)
+	require.Len(t, targets, 1)
+	assert.Equal(t, fleet.WebhookTargetGeneric, targets[0].Kind)
+	assert.True(t, targets[0].Required)
+
+	var cfg GenericTargetConfig
+	require.NoError(t, json.Unmarshal(targets[0].Config, &cfg))
+	assert.Equal(t, "https://example.com/hook", cfg.DestinationURL)
+	assert.Equal(t, "shh", cfg.Secret)
+}
+
+func TestWebhookTargetsPrefersExplicitTargets(t *testing.T) {
+	appConfig := &fleet.AppConfig{}
+	appConfig.WebhookSettings.FailingPoliciesWebhook.DestinationURL = "https://example.com/hook"
+	appConfig.WebhookSettings.FailingPoliciesWebhook.Targets = []fleet.WebhookTarget{
+		{Kind: fleet.WebhookTargetSlack, Required: false},
+	}
+
+	targets := webhookTargets(appConfig)
+	require.Len(t, targets, 1)
+	assert.Equal(t, fleet.WebhookTargetSlack, targets[0].Kind)
+}
+
+func TestNotifierForTarget(t *testing.T) {
+	cfg, err := json.Marshal(SlackTargetConfig{WebhookURL: "https://hooks.slack.test/abc"})
+	require.NoError(t, err)
+
+	notifier, err := notifierForTarget(fleet.WebhookTarget{Kind: fleet.WebhookTargetSlack, Config: cfg})
+	require.NoError(t, err)
+
+	slackNotifier, ok := notifier.(*SlackNotifier)
+	require.True(t, ok)
+	assert.Equal(t, "https://hooks.slack.test/abc", slackNotifier.Config.WebhookURL)
+}
+
+func TestNotifierForTargetUnknownKind(t *testing.T) {
+	_, err := notifierForTarget(fleet.WebhookTarget{Kind: "bogus"})
+	assert.Error(t, err)
+}
+
+func TestSlackBlockKitMessage(t *testing.T) {
+	payload := FailingPoliciesPayload{
+		Policy:       &fleet.Policy{Name: "Disk encryption enabled"},
+		FailingHosts: []FailingHost{{ID: 1}, {ID: 2}},
+		PolicyURL:    "https://fleet.example.com/policies/1",
+	}
+
+	msg := slackBlockKitMessage(payload)
+	require.Len(t, msg.Blocks, 1)
+	assert.Contains(t, msg.Blocks[0].Text.Text, "Disk encryption enabled")
+	assert.Contains(t, msg.Blocks[0].Text.Text, "https://fleet.example.com/policies/1")
+	assert.Contains(t, msg.Blocks[0].Text.Text, "2 host(s)")
+}
+
+func TestPagerDutyDedupKeyStableByPolicy(t *testing.T) {
+	payload := FailingPoliciesPayload{Policy: &fleet.Policy{ID: 42}}
+	assert.Equal(t, pagerDutyDedupKey(payload), pagerDutyDedupKey(payload))
+	assert.Equal(t, "fleet-failing-policy-42", pagerDutyDedupKey(payload))
+}
+
+func TestJiraIssueURL(t *testing.T) {
+	cases := []struct {
+		serverURL string
+		want      string
+	}{
+		{"https://fleet.atlassian.net", "https://fleet.atlassian.net/rest/api/2/issue"},
+		{"fleet.atlassian.net", "https://fleet.atlassian.net/rest/api/2/issue"},
+		{"https://fleet.atlassian.net/", "https://fleet.atlassian.net/rest/api/2/issue"},
+	}
+	for _, c := range cases {
+		got, err := jiraIssueURL(c.serverURL)
+		require.NoError(t, err)
+		assert.Equal(t, c.want, got)
+	}
+}
@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+)
+
+// PagerDutyTargetConfig configures delivery to the PagerDuty Events API v2.
+type PagerDutyTargetConfig struct {
+	IntegrationKey string `json:"integration_key"`
+}
+
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutyNotifier triggers a PagerDuty event for a failing policy. The
+// dedup key is derived from the policy ID alone (not the host list) so that
+// repeated triggers for the same policy aggregate into a single open
+// incident instead of paging once per run.
+type PagerDutyNotifier struct {
+	Config PagerDutyTargetConfig
+}
+
+func (n *PagerDutyNotifier) Notify(ctx context.Context, payload FailingPoliciesPayload) error {
+	event := pagerDutyEvent{
+		RoutingKey:  n.Config.IntegrationKey,
+		EventAction: "trigger",
+		DedupKey:    pagerDutyDedupKey(payload),
+		Payload: pagerDutyEventPayload{
+			Summary:  pagerDutySummary(payload),
+			Source:   "fleet",
+			Severity: "warning",
+		},
+	}
+	return deliverWebhook(ctx, pagerDutyEventsURL, "", &event)
+}
+
+func pagerDutyDedupKey(payload FailingPoliciesPayload) string {
+	if payload.Policy == nil {
+		return "fleet-failing-policy-unknown"
+	}
+	return fmt.Sprintf("fleet-failing-policy-%d", payload.Policy.ID)
+}
+
+func pagerDutySummary(payload FailingPoliciesPayload) string {
+	policyName := "unknown policy"
+	if payload.Policy != nil {
+		policyName = payload.Policy.Name
+	}
+	return fmt.Sprintf("Fleet policy %q is failing on %d host(s)", policyName, len(payload.FailingHosts))
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string                `json:"routing_key"`
+	EventAction string                `json:"event_action"`
+	DedupKey    string                `json:"dedup_key"`
+	Payload     pagerDutyEventPayload `json:"payload"`
+}
+
+type pagerDutyEventPayload struct {
+	Summary  string `json:"summary"`
+	Source   string `json:"source"`
+	Severity string `json:"severity"`
+}
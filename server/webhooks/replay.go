@@ -0,0 +1,74 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fleetdm/fleet/v4/server/contexts/ctxerr"
+	"github.com/fleetdm/fleet/v4/server/fleet"
+)
+
+// ReplayResult reports the outcome of re-sending a single dead-lettered
+// webhook delivery.
+type ReplayResult struct {
+	ID  uint   `json:"id"`
+	URL string `json:"url"`
+	// Err is the delivery error, if replay failed. Empty means the replay
+	// succeeded and the dead-letter entry was removed.
+	Err string `json:"error,omitempty"`
+}
+
+// ReplayDeadLetters re-attempts delivery of dead-lettered webhook(s). When
+// id is nil, every dead-lettered delivery is replayed; otherwise only the
+// entry with that ID is. A successful replay removes the dead-letter
+// record; a failed one leaves it in place, so it can be replayed again
+// later, and reports the error back to the caller instead of failing the
+// whole batch.
+func ReplayDeadLetters(ctx context.Context, ds fleet.Datastore, id *uint) ([]ReplayResult, error) {
+	deadLetters, err := ds.ListWebhookDeadLetters(ctx, id)
+	if err != nil {
+		return nil, ctxerr.Wrap(ctx, err, "listing dead-lettered webhooks")
+	}
+
+	results := make([]ReplayResult, 0, len(deadLetters))
+	for _, dl := range deadLetters {
+		result := ReplayResult{ID: dl.ID, URL: dl.URL}
+
+		if err := replayOne(ctx, dl); err != nil {
+			result.Err = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		if err := ds.DeleteWebhookDeadLetter(ctx, dl.ID); err != nil {
+			result.Err = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// replayOne re-delivers a single dead-lettered webhook. When dl.Kind is
+// set, delivery goes through the same Notifier the original send used, so
+// kind-specific auth (JIRA basic auth, an HMAC secret, ...) is rebuilt
+// rather than lost; older dead letters with no Kind recorded fall back to
+// a raw re-POST of the stored payload bytes.
+func replayOne(ctx context.Context, dl fleet.WebhookDeadLetter) error {
+	if dl.Kind == "" {
+		return deliverWebhook(ctx, dl.URL, "", json.RawMessage(dl.Payload))
+	}
+
+	notifier, err := notifierForTarget(fleet.WebhookTarget{Kind: dl.Kind, Config: dl.Config})
+	if err != nil {
+		return fmt.Errorf("building notifier for dead letter %d: %w", dl.ID, err)
+	}
+
+	var payload FailingPoliciesPayload
+	if err := json.Unmarshal(dl.Payload, &payload); err != nil {
+		return fmt.Errorf("decode dead letter %d payload: %w", dl.ID, err)
+	}
+
+	return notifier.Notify(ctx, payload)
+}
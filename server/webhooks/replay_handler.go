@@ -0,0 +1,43 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/fleetdm/fleet/v4/server/fleet"
+)
+
+// replayResponse is the JSON body ReplayDeadLetterHandler writes, matching
+// the shape service.Client.ReplayDeadLetterWebhooks expects back.
+type replayResponse struct {
+	Results []ReplayResult `json:"results"`
+}
+
+// ReplayDeadLetterHandler serves POST
+// /api/latest/fleet/webhooks/dead_letter/replay, backing `fleetctl webhooks
+// replay`. An optional ?id= query param limits the replay to a single
+// dead-lettered delivery; omitting it replays all of them.
+func ReplayDeadLetterHandler(ds fleet.Datastore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id *uint
+		if raw := r.URL.Query().Get("id"); raw != "" {
+			parsed, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				http.Error(w, "invalid id", http.StatusBadRequest)
+				return
+			}
+			v := uint(parsed)
+			id = &v
+		}
+
+		results, err := ReplayDeadLetters(r.Context(), ds, id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(replayResponse{Results: results})
+	}
+}
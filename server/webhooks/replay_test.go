@@ -0,0 +1,95 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fleetdm/fleet/v4/server/datastore/inmem"
+	"github.com/fleetdm/fleet/v4/server/fleet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayDeadLettersRawFallback(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ds, err := inmem.New("")
+	require.NoError(t, err)
+	require.NoError(t, ds.NewWebhookDeadLetter(context.Background(), fleet.WebhookDeadLetter{
+		URL:     server.URL,
+		Payload: []byte(`{"hello":"world"}`),
+	}))
+
+	results, err := ReplayDeadLetters(context.Background(), ds, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Empty(t, results[0].Err)
+	assert.JSONEq(t, `{"hello":"world"}`, string(received))
+
+	remaining, err := ds.ListWebhookDeadLetters(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, remaining, "a successful replay should remove the dead letter")
+}
+
+func TestReplayDeadLettersReconstructsNotifierByKind(t *testing.T) {
+	var gotAuthHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthHeader = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg, err := json.Marshal(JiraTargetConfig{ServerURL: server.URL, ProjectKey: "FLEET", Username: "bot", APIToken: "secret-token"})
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(FailingPoliciesPayload{Policy: &fleet.Policy{Name: "Disk encryption enabled"}})
+	require.NoError(t, err)
+
+	ds, err := inmem.New("")
+	require.NoError(t, err)
+	require.NoError(t, ds.NewWebhookDeadLetter(context.Background(), fleet.WebhookDeadLetter{
+		URL:     server.URL,
+		Kind:    fleet.WebhookTargetJira,
+		Config:  cfg,
+		Payload: payload,
+	}))
+
+	results, err := ReplayDeadLetters(context.Background(), ds, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Empty(t, results[0].Err)
+	assert.NotEmpty(t, gotAuthHeader, "replaying a jira dead letter should re-sign with basic auth, not a raw re-POST")
+}
+
+func TestReplayDeadLettersKeepsFailedEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ds, err := inmem.New("")
+	require.NoError(t, err)
+	require.NoError(t, ds.NewWebhookDeadLetter(context.Background(), fleet.WebhookDeadLetter{
+		URL:     server.URL,
+		Payload: []byte(`{}`),
+	}))
+	ctx := withDeliveryLimits(context.Background(), 1, 0)
+
+	results, err := ReplayDeadLetters(ctx, ds, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.NotEmpty(t, results[0].Err)
+
+	remaining, err := ds.ListWebhookDeadLetters(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, remaining, 1, "a failed replay should leave the dead letter in place")
+}
@@ -0,0 +1,57 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+)
+
+// SlackTargetConfig configures delivery to a Slack incoming webhook URL.
+type SlackTargetConfig struct {
+	WebhookURL string `json:"webhook_url"`
+}
+
+// SlackNotifier renders a failing-policies notification as a Slack Block
+// Kit message and posts it to an incoming webhook URL.
+type SlackNotifier struct {
+	Config SlackTargetConfig
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, payload FailingPoliciesPayload) error {
+	msg := slackBlockKitMessage(payload)
+	return deliverWebhook(ctx, n.Config.WebhookURL, "", &msg)
+}
+
+type slackMessage struct {
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type string     `json:"type"`
+	Text *slackText `json:"text,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func slackBlockKitMessage(payload FailingPoliciesPayload) slackMessage {
+	policyName := "unknown policy"
+	if payload.Policy != nil {
+		policyName = payload.Policy.Name
+	}
+
+	text := fmt.Sprintf(
+		"*Policy failing: <%s|%s>*\n%d host(s) currently failing this policy.",
+		payload.PolicyURL, policyName, len(payload.FailingHosts),
+	)
+
+	return slackMessage{
+		Blocks: []slackBlock{
+			{
+				Type: "section",
+				Text: &slackText{Type: "mrkdwn", Text: text},
+			},
+		},
+	}
+}